@@ -13,8 +13,13 @@ import (
 
 var Default = ValidateAndBuild
 
-func ValidateAndBuild() {
+func ValidateAndBuild() error {
 	mg.SerialDeps(Validate, Build)
+
+	if os.Getenv("CREATE_BOM") == "1" {
+		return SBOM()
+	}
+	return nil
 }
 
 func Validate() error {
@@ -0,0 +1,197 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// variantResult is one row of the BuildMatrix summary table.
+type variantResult struct {
+	versionMin string
+	platform   string
+	zipPath    string
+}
+
+// BuildMatrix builds one zip per `[[build.matrix]]` entry/platform
+// combination in blender_manifest.toml, each constrained to that entry's
+// blender_version_min/blender_version_max/platforms. Modeled on
+// go-ethereum's per-arch `install` fan-out: every variant is built in
+// parallel via mg.Deps, and a summary table is printed once they're all done.
+func BuildMatrix() error {
+	manifest := loadManifest()
+	if len(manifest.Build.Matrix) == 0 {
+		return fmt.Errorf("blender_manifest.toml has no [[build.matrix]] entries")
+	}
+
+	var deps []interface{}
+	var results []variantResult
+	for _, entry := range manifest.Build.Matrix {
+		for _, platform := range entry.Platforms {
+			zipPath := variantZipPath(manifest, entry.BlenderVersionMin, platform)
+			results = append(results, variantResult{entry.BlenderVersionMin, platform, zipPath})
+			deps = append(deps, mg.F(buildVariant, entry.BlenderVersionMin, entry.BlenderVersionMax, platform, zipPath))
+		}
+	}
+	mg.Deps(deps...)
+
+	printMatrixSummary(results)
+	return nil
+}
+
+// ValidateMatrix runs `extension validate` against every rewritten,
+// per-variant manifest, without building anything.
+func ValidateMatrix() error {
+	manifest := loadManifest()
+	if len(manifest.Build.Matrix) == 0 {
+		return fmt.Errorf("blender_manifest.toml has no [[build.matrix]] entries")
+	}
+
+	var deps []interface{}
+	for _, entry := range manifest.Build.Matrix {
+		for _, platform := range entry.Platforms {
+			deps = append(deps, mg.F(validateVariant, entry.BlenderVersionMin, entry.BlenderVersionMax, platform))
+		}
+	}
+	mg.Deps(deps...)
+	return nil
+}
+
+func variantZipPath(manifest BlenderManifest, versionMin, platform string) string {
+	zipName := fmt.Sprintf("you-are-autosave-v%s-blender%s-%s.zip", manifest.Version, versionMin, platform)
+	return filepath.Join("dist", zipName)
+}
+
+func buildVariant(versionMin, versionMax, platform, zipPath string) error {
+	variantDir, cleanup, err := rewriteVariantManifest(versionMin, versionMax, platform)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	zipDir := filepath.Dir(zipPath)
+	if err := os.MkdirAll(zipDir, 0o777); err != nil {
+		return err
+	}
+
+	fmt.Printf("Creating %s\n", zipPath)
+	return sh.RunV("blender", "--command", "extension", "build",
+		"--source-dir", variantDir,
+		"--output-filepath", zipPath,
+	)
+}
+
+func validateVariant(versionMin, versionMax, platform string) error {
+	variantDir, cleanup, err := rewriteVariantManifest(versionMin, versionMax, platform)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	return sh.RunV("blender", "--command", "extension", "validate",
+		"--source-dir", variantDir,
+	)
+}
+
+// rewriteVariantManifest copies the addon tree into a temp directory and
+// rewrites its blender_manifest.toml with the given version/platform
+// constraints, returning the temp directory and a cleanup func.
+func rewriteVariantManifest(versionMin, versionMax, platform string) (dir string, cleanup func(), err error) {
+	tmpDir, err := os.MkdirTemp("", "you-are-autosave-matrix-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot create temp build dir: %w", err)
+	}
+	cleanup = func() { os.RemoveAll(tmpDir) }
+
+	if err := copyAddonTree(".", tmpDir); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("cannot copy addon tree: %w", err)
+	}
+
+	docBytes, err := os.ReadFile(filepath.Join(tmpDir, "blender_manifest.toml"))
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("cannot read manifest copy: %w", err)
+	}
+
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(docBytes, &doc); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("cannot parse manifest copy: %w", err)
+	}
+
+	doc["blender_version_min"] = versionMin
+	doc["blender_version_max"] = versionMax
+	doc["platforms"] = []string{platform}
+
+	rewritten, err := toml.Marshal(doc)
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("cannot marshal rewritten manifest: %w", err)
+	}
+
+	manifestPath := filepath.Join(tmpDir, "blender_manifest.toml")
+	if err := os.WriteFile(manifestPath, rewritten, 0o644); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("cannot write rewritten manifest: %w", err)
+	}
+
+	return tmpDir, cleanup, nil
+}
+
+func copyAddonTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if excludedFromBOM[d.Name()] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o777)
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func printMatrixSummary(results []variantResult) {
+	fmt.Println()
+	fmt.Println("Blender version   Platform        Zip")
+	for _, r := range results {
+		fmt.Printf("%-17s %-15s %s\n", r.versionMin, r.platform, r.zipPath)
+	}
+}
@@ -0,0 +1,163 @@
+//go:build mage
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// versionLineRE matches the top-level `version = "X.Y.Z"` line in
+// blender_manifest.toml. go-toml/v2's Unmarshal/Marshal round-trip drops
+// comments and reorders keys, so bumping the version is done with a
+// targeted line replacement instead, which leaves everything else in the
+// file untouched.
+var versionLineRE = regexp.MustCompile(`(?m)^(\s*version\s*=\s*")([^"]+)(")`)
+
+// BumpMajor bumps the manifest's major version, resetting minor and patch
+// to 0 (e.g. 1.2.3 -> 2.0.0).
+//
+// Mage targets can't declare variadic parameters, so flags are parsed from
+// os.Args directly: `mage bumpMajor -tag`.
+func BumpMajor() error {
+	return bumpVersion(func(major, minor, patch int) (int, int, int) {
+		return major + 1, 0, 0
+	})
+}
+
+// BumpMinor bumps the manifest's minor version, resetting patch to 0
+// (e.g. 1.2.3 -> 1.3.0).
+func BumpMinor() error {
+	return bumpVersion(func(major, minor, patch int) (int, int, int) {
+		return major, minor + 1, 0
+	})
+}
+
+// BumpPatch bumps the manifest's patch version (e.g. 1.2.3 -> 1.2.4).
+func BumpPatch() error {
+	return bumpVersion(func(major, minor, patch int) (int, int, int) {
+		return major, minor, patch + 1
+	})
+}
+
+// SetVersion sets the manifest's version to an explicit X.Y.Z value.
+//
+// Mage targets can't declare variadic parameters, so flags are parsed from
+// os.Args directly and the new version is taken as the first non-flag
+// argument, which means flags must come first: `mage setversion -tag
+// 2.0.0`.
+//
+// All of BumpMajor/BumpMinor/BumpPatch/SetVersion refuse to run against a
+// dirty working tree unless -force is passed. With -tag they also create a
+// git tag v<new version> on the current HEAD; the manifest change itself is
+// left uncommitted, so that commit still carries the old version.
+func SetVersion() error {
+	fs := flag.NewFlagSet("setversion", flag.ContinueOnError)
+	force := fs.Bool("force", false, "bump even if the working tree is dirty")
+	tag := fs.Bool("tag", false, "create a git tag for the new version")
+	if err := fs.Parse(targetArgs()); err != nil {
+		return err
+	}
+	rest := fs.Args()
+	if len(rest) != 1 {
+		return fmt.Errorf("setversion needs exactly one argument, the new version (e.g. `mage setversion 2.0.0`)")
+	}
+
+	newVersion := rest[0]
+	if _, _, _, err := parseSemver(newVersion); err != nil {
+		return err
+	}
+
+	return applyVersionBump(*force, *tag, newVersion)
+}
+
+func bumpVersion(next func(major, minor, patch int) (int, int, int)) error {
+	fs := flag.NewFlagSet("bump", flag.ContinueOnError)
+	force := fs.Bool("force", false, "bump even if the working tree is dirty")
+	tag := fs.Bool("tag", false, "create a git tag for the new version")
+	if err := fs.Parse(targetArgs()); err != nil {
+		return err
+	}
+
+	manifest := loadManifest()
+	major, minor, patch, err := parseSemver(manifest.Version)
+	if err != nil {
+		return err
+	}
+
+	newMajor, newMinor, newPatch := next(major, minor, patch)
+	newVersion := fmt.Sprintf("%d.%d.%d", newMajor, newMinor, newPatch)
+
+	return applyVersionBump(*force, *tag, newVersion)
+}
+
+func applyVersionBump(force, tag bool, newVersion string) error {
+	if !force {
+		if err := requireCleanWorkingTree(); err != nil {
+			return err
+		}
+	}
+
+	if err := rewriteVersion(newVersion); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bumped version to %s\n", newVersion)
+
+	if tag {
+		tagName := "v" + newVersion
+		if err := sh.RunV("git", "tag", tagName); err != nil {
+			return fmt.Errorf("cannot create tag %s: %w", tagName, err)
+		}
+		fmt.Printf("Tagged %s\n", tagName)
+	}
+
+	return nil
+}
+
+func rewriteVersion(newVersion string) error {
+	docBytes, err := os.ReadFile("blender_manifest.toml")
+	if err != nil {
+		return fmt.Errorf("cannot read blender manifest: %w", err)
+	}
+
+	if !versionLineRE.Match(docBytes) {
+		return fmt.Errorf("cannot find a `version = \"...\"` line in blender_manifest.toml")
+	}
+
+	rewritten := versionLineRE.ReplaceAll(docBytes, []byte(`${1}`+newVersion+`${3}`))
+	return os.WriteFile("blender_manifest.toml", rewritten, 0o644)
+}
+
+func parseSemver(version string) (major, minor, patch int, err error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("version %q is not in X.Y.Z form", version)
+	}
+
+	nums := make([]int, 3)
+	for i, part := range parts {
+		nums[i], err = strconv.Atoi(part)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("version %q is not in X.Y.Z form: %w", version, err)
+		}
+	}
+	return nums[0], nums[1], nums[2], nil
+}
+
+func requireCleanWorkingTree() error {
+	out, err := sh.Output("git", "status", "--porcelain")
+	if err != nil {
+		return fmt.Errorf("cannot check git status: %w", err)
+	}
+	if strings.TrimSpace(out) != "" {
+		return fmt.Errorf("working tree is dirty, commit or stash first (or pass -force)")
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build mage
+
+package main
+
+import "os"
+
+// targetArgs returns the command-line arguments passed to the running mage
+// target, with the binary path and the matched target name stripped off.
+//
+// Mage target functions run in the same process mage's own dispatcher does,
+// so os.Args is left exactly as typed on the command line: [mage-binary,
+// target-name, ...rest]. Target functions can't declare variadic
+// parameters, so any target that wants flags (e.g. `mage release
+// -dry-run`) has to parse os.Args itself — but os.Args[1:] still starts
+// with the target name, which isn't a flag and makes flag.Parse stop
+// before it reaches anything useful. targetArgs() skips past it so
+// flag.NewFlagSet(...).Parse(targetArgs()) sees only ["-dry-run", ...].
+func targetArgs() []string {
+	if len(os.Args) <= 2 {
+		return nil
+	}
+	return os.Args[2:]
+}
@@ -0,0 +1,127 @@
+//go:build mage
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
+)
+
+const addonName = "you-are-autosave"
+
+// Install builds the extension and side-loads it into the local Blender
+// user extensions directory, so it's available without publishing a release.
+func Install() error {
+	mg.SerialDeps(ValidateAndBuild)
+
+	zipPath := buildZipPath()
+	return sh.RunV("blender", "--command", "extension", "install-file",
+		"--repo", "user_default",
+		"--enable",
+		zipPath,
+	)
+}
+
+// Uninstall removes the addon from the local Blender user extensions
+// directory. Pass -n to print what would be removed without touching
+// anything.
+//
+// It refuses to act on anything outside a validated extensions directory.
+//
+// Mage targets can't declare variadic parameters, so the -n flag is parsed
+// from os.Args directly: `mage uninstall -n`.
+func Uninstall() error {
+	fs := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+	dryRun := fs.Bool("n", false, "print what would be removed without removing it")
+	if err := fs.Parse(targetArgs()); err != nil {
+		return err
+	}
+
+	target, err := addonInstallDir()
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Printf("[dry-run] would remove %s\n", target)
+		return nil
+	}
+
+	fmt.Printf("Removing %s\n", target)
+	return os.RemoveAll(target)
+}
+
+// Dev symlinks the working tree into the local Blender user extensions
+// directory, so iterating on the addon doesn't require rebuilding the zip.
+func Dev() error {
+	target, err := addonInstallDir()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(target); err == nil {
+		return fmt.Errorf("%s already exists; run `mage uninstall` first", target)
+	}
+
+	src, err := filepath.Abs(".")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o777); err != nil {
+		return err
+	}
+
+	fmt.Printf("Symlinking %s -> %s\n", target, src)
+	return os.Symlink(src, target)
+}
+
+// addonInstallDir resolves <user extensions dir>/user_default/you-are-autosave,
+// validating that the extensions directory itself looks genuine before
+// Uninstall or Dev are allowed to touch anything under it.
+func addonInstallDir() (string, error) {
+	extensionsDir, err := userExtensionsDir()
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(extensionsDir)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("refusing to use %s: not a directory (is Blender installed?)", extensionsDir)
+	}
+
+	return filepath.Join(extensionsDir, "user_default", addonName), nil
+}
+
+// userExtensionsDir finds Blender's per-user extensions directory: the
+// BLENDER_USER_EXTENSIONS env var if set, falling back to `blender
+// --command extension` and then to the OS-appropriate default location.
+func userExtensionsDir() (string, error) {
+	if dir := os.Getenv("BLENDER_USER_EXTENSIONS"); dir != "" {
+		return dir, nil
+	}
+
+	if out, err := sh.Output("blender", "--command", "extension", "path", "user_default"); err == nil && out != "" {
+		return out, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(home, "AppData", "Roaming", "Blender Foundation", "Blender", "extensions"), nil
+	case "darwin":
+		return filepath.Join(home, "Library", "Application Support", "Blender", "extensions"), nil
+	default:
+		return filepath.Join(home, ".config", "blender", "extensions"), nil
+	}
+}
@@ -16,6 +16,23 @@ type BlenderManifest struct {
 	SchemaVersion int
 	Version       string
 	Name          string
+	Build         BuildSection
+	Wheels        []string
+}
+
+// BuildSection mirrors the `[build]` table, which controls which files
+// `blender --command extension build` includes in the packaged zip.
+type BuildSection struct {
+	Paths  []string      `toml:"paths"`
+	Matrix []MatrixEntry `toml:"matrix"`
+}
+
+// MatrixEntry is one row of `[[build.matrix]]`: a Blender version range and
+// platform set that BuildMatrix packages as its own variant zip.
+type MatrixEntry struct {
+	BlenderVersionMin string   `toml:"blender_version_min"`
+	BlenderVersionMax string   `toml:"blender_version_max"`
+	Platforms         []string `toml:"platforms"`
 }
 
 func Info() {
@@ -24,6 +41,15 @@ func Info() {
 	fmt.Printf("Version: %s\n", manifest.Version)
 }
 
+// Version prints just the version from blender_manifest.toml. This used to
+// live in a root-level mage.go, but a root mage file takes precedence over
+// the magefiles package and was shadowing every target in this directory, so
+// it was merged in here.
+func Version() {
+	manifest := loadManifest()
+	fmt.Println(manifest.Version)
+}
+
 func loadManifest() BlenderManifest {
 	docBytes, err := os.ReadFile("blender_manifest.toml")
 	if err != nil {
@@ -0,0 +1,199 @@
+//go:build mage
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
+)
+
+// Signer detached-signs a file and returns the path to the signature it
+// produced. Implementations are chosen by the Release target's -signer flag.
+type Signer interface {
+	Sign(path string) (sigPath string, err error)
+}
+
+// Uploader publishes the release artifacts (the zip plus its signature and
+// checksum files) to some destination. Implementations are chosen by the
+// Release target's -upload flag.
+type Uploader interface {
+	Upload(dryRun bool, paths ...string) error
+}
+
+// gpgSigner shells out to gpg to produce an ASCII-armored detached signature.
+type gpgSigner struct {
+	keyID string
+}
+
+func (s gpgSigner) Sign(path string) (string, error) {
+	if s.keyID == "" {
+		return "", fmt.Errorf("no signing key configured, set EXTENSION_SIGNING_KEY")
+	}
+
+	sigPath := path + ".asc"
+	err := sh.RunV("gpg", "--batch", "--yes", "--local-user", s.keyID,
+		"--detach-sign", "--armor", "--output", sigPath, path)
+	if err != nil {
+		return "", fmt.Errorf("gpg signing failed: %w", err)
+	}
+	return sigPath, nil
+}
+
+// blenderUploader PUTs artifacts to the Blender extensions platform API.
+type blenderUploader struct {
+	endpoint string
+}
+
+func (u blenderUploader) Upload(dryRun bool, paths ...string) error {
+	endpoint := u.endpoint
+	if endpoint == "" {
+		endpoint = "https://extensions.blender.org/api/v1/extensions/you-are-autosave/upload"
+	}
+
+	for _, path := range paths {
+		if dryRun {
+			fmt.Printf("[dry-run] PUT %s -> %s\n", path, endpoint)
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("cannot open %s: %w", path, err)
+		}
+		req, err := http.NewRequest(http.MethodPut, endpoint+"/"+filepath.Base(path), f)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("cannot build upload request for %s: %w", path, err)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("upload of %s failed: %w", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("upload of %s failed: %s", path, resp.Status)
+		}
+		fmt.Printf("Uploaded %s\n", path)
+	}
+	return nil
+}
+
+// s3Uploader uploads artifacts to an S3-compatible bucket via the `aws` CLI,
+// so it works against any endpoint the caller's AWS config points at.
+type s3Uploader struct {
+	bucket string
+	prefix string
+}
+
+func (u s3Uploader) Upload(dryRun bool, paths ...string) error {
+	for _, path := range paths {
+		dest := fmt.Sprintf("s3://%s/%s", u.bucket, filepath.Join(u.prefix, filepath.Base(path)))
+		if dryRun {
+			fmt.Printf("[dry-run] aws s3 cp %s %s\n", path, dest)
+			continue
+		}
+		if err := sh.RunV("aws", "s3", "cp", path, dest); err != nil {
+			return fmt.Errorf("upload of %s to %s failed: %w", path, dest, err)
+		}
+	}
+	return nil
+}
+
+func newSigner(name string) (Signer, error) {
+	switch name {
+	case "", "gpg":
+		return gpgSigner{keyID: os.Getenv("EXTENSION_SIGNING_KEY")}, nil
+	default:
+		return nil, fmt.Errorf("unknown signer %q", name)
+	}
+}
+
+func newUploader(dest string) Uploader {
+	if strings.HasPrefix(dest, "s3://") {
+		rest := strings.TrimPrefix(dest, "s3://")
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		return s3Uploader{bucket: bucket, prefix: prefix}
+	}
+	return blenderUploader{endpoint: dest}
+}
+
+// Release builds the extension, signs it and uploads the zip, signature and
+// checksum to the configured destination.
+//
+// Mage targets can't declare variadic parameters, so flags are parsed from
+// os.Args directly: `mage release -signer=gpg -upload=s3://bucket/prefix
+// -dry-run`.
+//
+// Flags: -signer <name> (default "gpg"), -upload <dest> (defaults to the
+// Blender extensions platform; pass an s3://bucket/prefix URL to upload to
+// an S3-compatible bucket instead), -dry-run (still builds the zip and
+// writes its .sha256 file, but prints the signing and upload steps instead
+// of doing them).
+func Release() error {
+	fs := flag.NewFlagSet("release", flag.ContinueOnError)
+	signerName := fs.String("signer", "gpg", "signer to use (gpg)")
+	uploadDest := fs.String("upload", "", "upload destination (blender extensions platform, or s3://bucket/prefix)")
+	dryRun := fs.Bool("dry-run", false, "print what would happen without signing or uploading")
+	if err := fs.Parse(targetArgs()); err != nil {
+		return err
+	}
+
+	mg.SerialDeps(ValidateAndBuild)
+
+	zipPath := buildZipPath()
+	shaPath, err := writeChecksum(zipPath)
+	if err != nil {
+		return err
+	}
+
+	artifacts := []string{zipPath, shaPath}
+
+	if !*dryRun {
+		signer, err := newSigner(*signerName)
+		if err != nil {
+			return err
+		}
+		sigPath, err := signer.Sign(zipPath)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, sigPath)
+	} else {
+		fmt.Printf("[dry-run] would sign %s with %q\n", zipPath, *signerName)
+	}
+
+	return newUploader(*uploadDest).Upload(*dryRun, artifacts...)
+}
+
+func writeChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", fmt.Errorf("cannot hash %s: %w", path, err)
+	}
+	sum := hex.EncodeToString(hasher.Sum(nil))
+
+	shaPath := path + ".sha256"
+	line := fmt.Sprintf("%s  %s\n", sum, filepath.Base(path))
+	if err := os.WriteFile(shaPath, []byte(line), 0o644); err != nil {
+		return "", fmt.Errorf("cannot write %s: %w", shaPath, err)
+	}
+	return shaPath, nil
+}
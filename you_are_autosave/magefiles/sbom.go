@@ -0,0 +1,246 @@
+//go:build mage
+
+package main
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cdxHash is a CycloneDX hash entry.
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// cdxComponent is a (trimmed) CycloneDX 1.5 component.
+type cdxComponent struct {
+	Type       string    `json:"type"`
+	Name       string    `json:"name"`
+	Version    string    `json:"version,omitempty"`
+	Hashes     []cdxHash `json:"hashes,omitempty"`
+	Properties []cdxProp `json:"properties,omitempty"`
+}
+
+type cdxProp struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// cdxBOM is the top-level CycloneDX 1.5 document.
+type cdxBOM struct {
+	BOMFormat   string         `json:"bomFormat"`
+	SpecVersion string         `json:"specVersion"`
+	Version     int            `json:"version"`
+	Metadata    cdxMetadata    `json:"metadata"`
+	Components  []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Component cdxComponent `json:"component"`
+}
+
+// excludedFromBOM are directories that are never part of the packaged
+// addon, even when blender_manifest.toml doesn't list a `[build] paths`.
+var excludedFromBOM = map[string]bool{
+	".git":      true,
+	"dist":      true,
+	"magefiles": true,
+}
+
+// SBOM writes a CycloneDX 1.5 bill of materials for the packaged addon to
+// dist/you-are-autosave-vX.Y.Z.cdx.json. It records every file Blender's
+// `extension build` would include, plus a library component for each wheel
+// listed in the manifest's `wheels`, named and versioned from that wheel's
+// METADATA. The dependencies a wheel itself declares (Requires-Dist) are not
+// listed.
+func SBOM() error {
+	manifest := loadManifest()
+
+	files, err := addonFiles(manifest)
+	if err != nil {
+		return fmt.Errorf("cannot enumerate addon files: %w", err)
+	}
+
+	components := make([]cdxComponent, 0, len(files))
+	for _, path := range files {
+		comp, err := fileComponent(path)
+		if err != nil {
+			return err
+		}
+		components = append(components, comp)
+	}
+
+	for _, wheelPath := range manifest.Wheels {
+		comp, err := wheelComponent(wheelPath)
+		if err != nil {
+			return fmt.Errorf("cannot read wheel %s: %w", wheelPath, err)
+		}
+		components = append(components, comp)
+	}
+
+	bom := cdxBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Component: cdxComponent{
+				Type:    "application",
+				Name:    manifest.Name,
+				Version: manifest.Version,
+			},
+		},
+		Components: components,
+	}
+
+	docBytes, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal SBOM: %w", err)
+	}
+
+	sbomName := fmt.Sprintf("you-are-autosave-v%s.cdx.json", manifest.Version)
+	sbomPath := filepath.Join("dist", sbomName)
+	if err := os.MkdirAll(filepath.Dir(sbomPath), 0o777); err != nil {
+		return fmt.Errorf("cannot create %s: %w", filepath.Dir(sbomPath), err)
+	}
+
+	fmt.Printf("Writing %s\n", sbomPath)
+	return os.WriteFile(sbomPath, docBytes, 0o644)
+}
+
+// addonFiles returns the files that would end up in the built zip: those
+// under the manifest's `[build] paths`, or every non-excluded file under
+// the repo root if no paths are configured. Wheel files (*.whl) are left
+// out; SBOM records wheels from the manifest's `wheels` list instead.
+func addonFiles(manifest BlenderManifest) ([]string, error) {
+	roots := manifest.Build.Paths
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	var files []string
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if excludedFromBOM[d.Name()] {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(path, ".whl") {
+				return nil
+			}
+			files = append(files, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func fileComponent(path string) (cdxComponent, error) {
+	hash, size, err := hashFile(path)
+	if err != nil {
+		return cdxComponent{}, fmt.Errorf("cannot hash %s: %w", path, err)
+	}
+
+	return cdxComponent{
+		Type: "file",
+		Name: path,
+		Hashes: []cdxHash{
+			{Alg: "SHA-256", Content: hash},
+		},
+		Properties: []cdxProp{
+			{Name: "size", Value: fmt.Sprintf("%d", size)},
+		},
+	}, nil
+}
+
+func hashFile(path string) (hash string, size int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	size, err = io.Copy(hasher, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), size, nil
+}
+
+// wheelComponent reads a bundled wheel's METADATA file to produce a real
+// "library" component instead of just recording the wheel as an opaque file.
+func wheelComponent(wheelPath string) (cdxComponent, error) {
+	hash, _, err := hashFile(wheelPath)
+	if err != nil {
+		return cdxComponent{}, err
+	}
+
+	name, version, err := wheelMetadata(wheelPath)
+	if err != nil {
+		return cdxComponent{}, err
+	}
+
+	return cdxComponent{
+		Type:    "library",
+		Name:    name,
+		Version: version,
+		Hashes: []cdxHash{
+			{Alg: "SHA-256", Content: hash},
+		},
+	}, nil
+}
+
+// wheelMetadata extracts the Name and Version fields from a wheel's
+// <dist-info>/METADATA file.
+func wheelMetadata(wheelPath string) (name, version string, err error) {
+	r, err := zip.OpenReader(wheelPath)
+	if err != nil {
+		return "", "", err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, ".dist-info/METADATA") {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return "", "", err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return "", "", err
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			switch {
+			case strings.HasPrefix(line, "Name: "):
+				name = strings.TrimSpace(strings.TrimPrefix(line, "Name: "))
+			case strings.HasPrefix(line, "Version: "):
+				version = strings.TrimSpace(strings.TrimPrefix(line, "Version: "))
+			}
+		}
+		return name, version, nil
+	}
+
+	return "", "", fmt.Errorf("no *.dist-info/METADATA found in %s", wheelPath)
+}